@@ -0,0 +1,102 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBoundedProducesOneEventPerInput(t *testing.T) {
+	ctx := context.Background()
+	in := make(chan int, 5)
+	for i := 1; i <= 5; i++ {
+		in <- i
+	}
+	close(in)
+
+	events := Bounded(ctx, in, 2, func(n int) (int, error) { return n * n, nil })
+
+	total := 0
+	count := 0
+	for ev := range events {
+		if ev.Err != nil {
+			t.Fatalf("unexpected error: %v", ev.Err)
+		}
+		total += ev.Data
+		count++
+	}
+	if count != 5 {
+		t.Fatalf("got %d events, want 5", count)
+	}
+	if want := 1 + 4 + 9 + 16 + 25; total != want {
+		t.Fatalf("got total %d, want %d", total, want)
+	}
+}
+
+func TestBoundedCapsConcurrency(t *testing.T) {
+	ctx := context.Background()
+	const n = 3
+
+	in := make(chan int, 20)
+	for i := 0; i < 20; i++ {
+		in <- i
+	}
+	close(in)
+
+	var inFlight, maxInFlight int64
+	events := Bounded(ctx, in, n, func(i int) (int, error) {
+		cur := atomic.AddInt64(&inFlight, 1)
+		for {
+			m := atomic.LoadInt64(&maxInFlight)
+			if cur <= m || atomic.CompareAndSwapInt64(&maxInFlight, m, cur) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt64(&inFlight, -1)
+		return i, nil
+	})
+
+	for range events {
+	}
+
+	if got := atomic.LoadInt64(&maxInFlight); got > n {
+		t.Fatalf("observed %d workers in flight at once, want at most %d", got, n)
+	}
+}
+
+func TestBoundedStopsPeersOnFirstError(t *testing.T) {
+	ctx := context.Background()
+	wantErr := errors.New("boom")
+
+	in := make(chan int, 100)
+	for i := 0; i < 100; i++ {
+		in <- i
+	}
+	close(in)
+
+	events := Bounded(ctx, in, 4, func(i int) (int, error) {
+		if i == 10 {
+			return 0, wantErr
+		}
+		time.Sleep(time.Millisecond)
+		return i, nil
+	})
+
+	var sawErr bool
+	count := 0
+	for ev := range events {
+		count++
+		if ev.Err != nil {
+			sawErr = true
+		}
+	}
+	if !sawErr {
+		t.Fatal("expected at least one Event to carry the worker error")
+	}
+	if count >= 100 {
+		t.Fatalf("got %d events, want fewer than 100 once the error stopped the peers", count)
+	}
+}
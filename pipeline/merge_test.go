@@ -0,0 +1,57 @@
+package pipeline
+
+import (
+	"context"
+	"sort"
+	"testing"
+)
+
+// source emits each value in values on its own goroutine, independent of
+// any other source — the scenario Merge is meant to fan in.
+func source(ctx context.Context, values ...int) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		for _, v := range values {
+			select {
+			case <-ctx.Done():
+				return
+			case out <- v:
+			}
+		}
+	}()
+	return out
+}
+
+func TestMergeFansInIndependentSources(t *testing.T) {
+	ctx := context.Background()
+
+	merged := Merge(ctx,
+		source(ctx, 1, 2, 3),
+		source(ctx, 4, 5),
+		source(ctx, 6),
+	)
+
+	var got []int
+	for v := range merged {
+		got = append(got, v)
+	}
+	sort.Ints(got)
+
+	want := []int{1, 2, 3, 4, 5, 6}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestMergeOfNoChannelsClosesImmediately(t *testing.T) {
+	merged := Merge[int](context.Background())
+	for range merged {
+		t.Fatal("expected no values from an empty Merge")
+	}
+}
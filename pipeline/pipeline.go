@@ -0,0 +1,105 @@
+// Package pipeline provides reusable concurrent pipeline stages built on
+// plain channels: bounded fan-out for parallel work, fan-in merging, and
+// other primitives for wiring stages together.
+package pipeline
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Event carries the result of a single pipeline stage invocation alongside
+// any error encountered producing it.
+type Event[T any] struct {
+	Data T
+	Err  error
+}
+
+// Bounded fans a stage out across n worker goroutines (defaulting to
+// runtime.GOMAXPROCS(0) when n <= 0), each pulling values from in and
+// applying worker to produce an Event[U] on the returned channel. The first
+// worker error cancels a context derived from ctx, stopping the remaining
+// workers; that error is also surfaced as the Err field of the Event that
+// carried it. The returned channel is closed once every worker has
+// returned.
+func Bounded[T, U any](ctx context.Context, in <-chan T, n int, worker func(T) (U, error)) <-chan Event[U] {
+	if n <= 0 {
+		n = runtime.GOMAXPROCS(0)
+	}
+
+	out := make(chan Event[U])
+	g, gctx := errgroup.WithContext(ctx)
+
+	for i := 0; i < n; i++ {
+		g.Go(func() error {
+			for {
+				select {
+				case <-gctx.Done():
+					return gctx.Err()
+				case v, ok := <-in:
+					if !ok {
+						return nil
+					}
+					u, err := worker(v)
+					select {
+					case <-gctx.Done():
+						return gctx.Err()
+					case out <- Event[U]{Data: u, Err: err}:
+					}
+					if err != nil {
+						return err
+					}
+				}
+			}
+		})
+	}
+
+	go func() {
+		defer close(out)
+		g.Wait()
+	}()
+
+	return out
+}
+
+// Merge multiplexes any number of inbound channels into a single outbound
+// channel using the classic fan-in pattern: one goroutine per input
+// forwards its values (respecting ctx.Done()), a sync.WaitGroup tracks
+// them, and a closer goroutine closes the output once every forwarder has
+// returned.
+func Merge[T any](ctx context.Context, chans ...<-chan T) <-chan T {
+	out := make(chan T)
+
+	var wg sync.WaitGroup
+	wg.Add(len(chans))
+	for _, c := range chans {
+		go func(c <-chan T) {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case v, ok := <-c:
+					if !ok {
+						return
+					}
+					select {
+					case <-ctx.Done():
+						return
+					case out <- v:
+					}
+				}
+			}
+		}(c)
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
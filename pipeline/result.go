@@ -0,0 +1,45 @@
+package pipeline
+
+import "context"
+
+// Result carries a single value through a chain of pipeline stages
+// alongside the first error observed producing it, if any. Once a stage
+// emits a Result with a non-nil Err, every stage downstream is expected to
+// forward it and stop, rather than continuing to process further values.
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+// Drain discards every remaining value from in. A stage that stops
+// consuming early, because it saw an error or its context was cancelled,
+// should call Drain on any channel it was still reading from so that
+// channel's producer goroutine does not block forever trying to send.
+func Drain[T any](in <-chan Result[T]) {
+	for range in {
+	}
+}
+
+// Run consumes a terminal pipeline stage to completion and reports the
+// outcome: the first error carried by one of its Results, ctx.Err() if ctx
+// is cancelled before the stage finishes, or nil if every Result arrived
+// without error.
+func Run[T any](ctx context.Context, in <-chan Result[T]) error {
+	for {
+		select {
+		case <-ctx.Done():
+			Drain(in)
+			return ctx.Err()
+		case r, ok := <-in:
+			if !ok {
+				// A stage that stopped because ctx was cancelled closes
+				// its channel too; that is not a successful finish.
+				return ctx.Err()
+			}
+			if r.Err != nil {
+				Drain(in)
+				return r.Err
+			}
+		}
+	}
+}
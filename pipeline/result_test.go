@@ -0,0 +1,112 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// sourceResults emits each value in values as a Result, in order.
+func sourceResults(ctx context.Context, values []int) <-chan Result[int] {
+	out := make(chan Result[int])
+	go func() {
+		defer close(out)
+		for _, v := range values {
+			select {
+			case <-ctx.Done():
+				return
+			case out <- Result[int]{Value: v}:
+			}
+		}
+	}()
+	return out
+}
+
+// failAfter forwards the first n values from in unchanged, then emits err
+// and stops, draining whatever is left of in so its producer doesn't leak.
+func failAfter(ctx context.Context, in <-chan Result[int], n int, err error) <-chan Result[int] {
+	out := make(chan Result[int])
+	go func() {
+		defer close(out)
+		count := 0
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case r, ok := <-in:
+				if !ok {
+					return
+				}
+				if count == n {
+					select {
+					case <-ctx.Done():
+					case out <- Result[int]{Err: err}:
+					}
+					Drain(in)
+					return
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case out <- r:
+				}
+				count++
+			}
+		}
+	}()
+	return out
+}
+
+func TestRunPropagatesFirstError(t *testing.T) {
+	ctx := context.Background()
+	wantErr := errors.New("boom")
+
+	stage := failAfter(ctx, sourceResults(ctx, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}), 4, wantErr)
+
+	if err := Run(ctx, stage); !errors.Is(err, wantErr) {
+		t.Fatalf("Run() error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestRunReturnsDeadlineExceeded(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	slow := make(chan Result[int])
+	go func() {
+		defer close(slow)
+		time.Sleep(200 * time.Millisecond)
+		select {
+		case <-ctx.Done():
+		case slow <- Result[int]{Value: 1}:
+		}
+	}()
+
+	if err := Run(ctx, slow); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Run() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestRunDoesNotLeakGoroutines(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ctx := context.Background()
+	wantErr := errors.New("boom")
+
+	for i := 0; i < 20; i++ {
+		stage := failAfter(ctx, sourceResults(ctx, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}), 4, wantErr)
+		if err := Run(ctx, stage); !errors.Is(err, wantErr) {
+			t.Fatalf("Run() error = %v, want %v", err, wantErr)
+		}
+	}
+
+	const grace = time.Second
+	deadline := time.Now().Add(grace)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Fatalf("goroutines leaked: before=%d after=%d", before, after)
+	}
+}
@@ -0,0 +1,168 @@
+package pipeline
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// scanNode is one node of the balanced binary tree Scan builds over its
+// input: a leaf holds a single value, an internal node spans [lo, hi) and
+// is the combination of its two children.
+type scanNode[T any] struct {
+	lo, hi      int
+	left, right *scanNode[T]
+	sum         T
+	prefix      T
+	hasPrefix   bool
+}
+
+// Scan performs a parallel inclusive prefix scan over in using op to
+// combine adjacent elements, via the classic two-pass PRAM algorithm: the
+// input is materialized into a slice and used to build a balanced binary
+// tree whose leaves are the input values. An up-sweep combines children
+// into parents bottom-up, level by level, then a down-sweep propagates
+// each node's prefix (the combination of everything to its left) back down
+// to the leaves, which are finally streamed out in their original order.
+// Work within each level is spread across up to runtime.GOMAXPROCS(0)
+// goroutines. Because the whole input must be read before the first
+// result can be produced, Scan is not suitable for unbounded or very large
+// channels.
+func Scan[T any](ctx context.Context, in <-chan T, op func(a, b T) T) <-chan T {
+	out := make(chan T)
+
+	go func() {
+		defer close(out)
+
+		values := drain(ctx, in)
+		if len(values) == 0 {
+			return
+		}
+
+		maxWorkers := runtime.GOMAXPROCS(0)
+		root, levels, leaves := buildScanTree(values)
+		upSweep(levels, maxWorkers, op)
+		downSweep(root, levels, maxWorkers, op)
+
+		for _, leaf := range leaves {
+			v := leaf.sum
+			if leaf.hasPrefix {
+				v = op(leaf.prefix, leaf.sum)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- v:
+			}
+		}
+	}()
+
+	return out
+}
+
+// drain reads every value off in, in order, until it closes or ctx is
+// cancelled.
+func drain[T any](ctx context.Context, in <-chan T) []T {
+	var values []T
+	for {
+		select {
+		case <-ctx.Done():
+			return values
+		case v, ok := <-in:
+			if !ok {
+				return values
+			}
+			values = append(values, v)
+		}
+	}
+}
+
+// buildScanTree builds a balanced binary tree over values, splitting each
+// range in half until a leaf remains. It returns the root, the nodes
+// grouped by depth (root at levels[0]) for the level-synchronized sweeps,
+// and the leaves in original left-to-right order for streaming results.
+func buildScanTree[T any](values []T) (root *scanNode[T], levels [][]*scanNode[T], leaves []*scanNode[T]) {
+	root = buildScanSubtree(0, len(values), values, 0, &levels, &leaves)
+	return root, levels, leaves
+}
+
+func buildScanSubtree[T any](lo, hi int, values []T, depth int, levels *[][]*scanNode[T], leaves *[]*scanNode[T]) *scanNode[T] {
+	for len(*levels) <= depth {
+		*levels = append(*levels, nil)
+	}
+	n := &scanNode[T]{lo: lo, hi: hi}
+	(*levels)[depth] = append((*levels)[depth], n)
+
+	if hi-lo == 1 {
+		n.sum = values[lo]
+		*leaves = append(*leaves, n)
+		return n
+	}
+
+	mid := lo + (hi-lo)/2
+	n.left = buildScanSubtree(lo, mid, values, depth+1, levels, leaves)
+	n.right = buildScanSubtree(mid, hi, values, depth+1, levels, leaves)
+	return n
+}
+
+// upSweep computes each internal node's sum as the combination of its two
+// children, processing levels from the leaves up to the root so that a
+// node's children are always finished before the node itself is combined.
+func upSweep[T any](levels [][]*scanNode[T], maxWorkers int, op func(a, b T) T) {
+	for d := len(levels) - 1; d >= 0; d-- {
+		runLevel(levels[d], maxWorkers, func(n *scanNode[T]) {
+			if n.left == nil {
+				return
+			}
+			n.sum = op(n.left.sum, n.right.sum)
+		})
+	}
+}
+
+// downSweep propagates each node's prefix (the combination of everything
+// strictly to its left) down to its children, processing levels from the
+// root down so that a node's prefix is always known before its children
+// need it. The root has nothing to its left, so it starts with no prefix.
+func downSweep[T any](root *scanNode[T], levels [][]*scanNode[T], maxWorkers int, op func(a, b T) T) {
+	root.hasPrefix = false
+
+	for d := 0; d < len(levels); d++ {
+		runLevel(levels[d], maxWorkers, func(n *scanNode[T]) {
+			if n.left == nil {
+				return
+			}
+			n.left.prefix, n.left.hasPrefix = n.prefix, n.hasPrefix
+			if n.hasPrefix {
+				n.right.prefix = op(n.prefix, n.left.sum)
+			} else {
+				n.right.prefix = n.left.sum
+			}
+			n.right.hasPrefix = true
+		})
+	}
+}
+
+// runLevel applies fn to every node in nodes, running up to maxWorkers of
+// them concurrently and waiting for all to finish before returning.
+func runLevel[T any](nodes []*scanNode[T], maxWorkers int, fn func(*scanNode[T])) {
+	if len(nodes) == 0 {
+		return
+	}
+	if maxWorkers <= 0 {
+		maxWorkers = 1
+	}
+
+	sem := make(chan struct{}, maxWorkers)
+	var wg sync.WaitGroup
+	wg.Add(len(nodes))
+	for _, n := range nodes {
+		n := n
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(n)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,101 @@
+package pipeline
+
+import (
+	"context"
+	"strconv"
+	"testing"
+)
+
+func sequentialScan(values []int, op func(a, b int) int) []int {
+	if len(values) == 0 {
+		return nil
+	}
+	out := make([]int, len(values))
+	out[0] = values[0]
+	for i := 1; i < len(values); i++ {
+		out[i] = op(out[i-1], values[i])
+	}
+	return out
+}
+
+func collectScan(ctx context.Context, values []int, op func(a, b int) int) []int {
+	in := make(chan int, len(values))
+	for _, v := range values {
+		in <- v
+	}
+	close(in)
+
+	var out []int
+	for v := range Scan(ctx, in, op) {
+		out = append(out, v)
+	}
+	return out
+}
+
+func TestScanMatchesSequential(t *testing.T) {
+	add := func(a, b int) int { return a + b }
+
+	sizes := []int{0, 1, 2, 3, 7, 8, 31, 100}
+	for _, n := range sizes {
+		values := make([]int, n)
+		for i := range values {
+			values[i] = i + 1
+		}
+
+		want := sequentialScan(values, add)
+		got := collectScan(context.Background(), values, add)
+
+		if len(got) != len(want) {
+			t.Fatalf("n=%d: got %d results, want %d", n, len(got), len(want))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("n=%d: result[%d] = %d, want %d", n, i, got[i], want[i])
+			}
+		}
+	}
+}
+
+func benchmarkValues(n int) []int {
+	values := make([]int, n)
+	for i := range values {
+		values[i] = i + 1
+	}
+	return values
+}
+
+func BenchmarkSequentialScan(b *testing.B) {
+	add := func(a, b int) int { return a + b }
+	for _, n := range []int{1_000, 100_000, 10_000_000} {
+		values := benchmarkValues(n)
+		b.Run(benchName(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sequentialScan(values, add)
+			}
+		})
+	}
+}
+
+func BenchmarkParallelScan(b *testing.B) {
+	add := func(a, b int) int { return a + b }
+	ctx := context.Background()
+	for _, n := range []int{1_000, 100_000, 10_000_000} {
+		values := benchmarkValues(n)
+		b.Run(benchName(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				collectScan(ctx, values, add)
+			}
+		})
+	}
+}
+
+func benchName(n int) string {
+	switch {
+	case n >= 1_000_000:
+		return strconv.Itoa(n/1_000_000) + "M"
+	case n >= 1_000:
+		return strconv.Itoa(n/1_000) + "k"
+	default:
+		return strconv.Itoa(n)
+	}
+}
@@ -0,0 +1,243 @@
+// Package stream implements a small, generic, channel-backed Stream type in
+// the spirit of Java's Stream API: a chain of operators (Filter, Map,
+// FlatMap, Take, Skip, ...) ending in a terminal operation (Reduce, ForEach,
+// ToSlice, ...). Stages are joined by unbuffered channels, so each stage
+// holds at most one element until the terminal operation drains the chain;
+// Sorted is the exception and reads its whole upstream as soon as it is
+// called.
+//
+// Every operator spawns a goroutine that reads from an inbound channel and
+// writes to a fresh outbound channel, so a chain like
+//
+//	stream.From(ctx, ints).Filter(isOdd).Map(double).ForEach(print)
+//
+// is really a pipeline of goroutines wired together by channels. All of
+// them select on a context derived from the one passed to From, so cancelling
+// the caller's context unwinds the whole chain. A Stream also carries its own
+// cancel func for that derived context: operators that can stop consuming
+// before the upstream is exhausted (Take, Any, All, None) call it once they
+// do, so abandoned upstream stages unwind instead of blocking forever on a
+// send nobody will ever receive.
+package stream
+
+import (
+	"context"
+	"sort"
+)
+
+// Stream is a lazily-evaluated sequence of values of type T, backed by a
+// channel and bound to a context. Operators build new Streams on top of an
+// existing one; apart from Sorted, each stage's goroutine moves at most one
+// element ahead of what a terminal operation has drained.
+type Stream[T any] struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	out    <-chan T
+}
+
+// From creates a Stream that emits the elements of items, in order, stopping
+// early if ctx is cancelled or if a downstream operator abandons the stream.
+func From[T any](ctx context.Context, items []T) *Stream[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for _, item := range items {
+			select {
+			case <-ctx.Done():
+				return
+			case out <- item:
+			}
+		}
+	}()
+	return &Stream[T]{ctx: ctx, cancel: cancel, out: out}
+}
+
+// Filter returns a Stream emitting only the elements of s for which pred
+// returns true.
+func (s *Stream[T]) Filter(pred func(T) bool) *Stream[T] {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for v := range s.out {
+			if !pred(v) {
+				continue
+			}
+			select {
+			case <-s.ctx.Done():
+				return
+			case out <- v:
+			}
+		}
+	}()
+	return &Stream[T]{ctx: s.ctx, cancel: s.cancel, out: out}
+}
+
+// Map applies fn to every element of s, producing a Stream of the results.
+// It is a free function rather than a method because Go methods cannot
+// introduce the additional type parameter U.
+func Map[T, U any](s *Stream[T], fn func(T) U) *Stream[U] {
+	out := make(chan U)
+	go func() {
+		defer close(out)
+		for v := range s.out {
+			select {
+			case <-s.ctx.Done():
+				return
+			case out <- fn(v):
+			}
+		}
+	}()
+	return &Stream[U]{ctx: s.ctx, cancel: s.cancel, out: out}
+}
+
+// FlatMap applies fn to every element of s and flattens the resulting slices
+// into a single Stream.
+func FlatMap[T, U any](s *Stream[T], fn func(T) []U) *Stream[U] {
+	out := make(chan U)
+	go func() {
+		defer close(out)
+		for v := range s.out {
+			for _, u := range fn(v) {
+				select {
+				case <-s.ctx.Done():
+					return
+				case out <- u:
+				}
+			}
+		}
+	}()
+	return &Stream[U]{ctx: s.ctx, cancel: s.cancel, out: out}
+}
+
+// Take returns a Stream of at most the first n elements of s. Once n
+// elements have been emitted, the upstream is cancelled so its goroutines
+// unwind instead of blocking forever on a send nobody will receive.
+func (s *Stream[T]) Take(n int) *Stream[T] {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		if n <= 0 {
+			s.cancel()
+			return
+		}
+		taken := 0
+		for v := range s.out {
+			select {
+			case <-s.ctx.Done():
+				return
+			case out <- v:
+			}
+			taken++
+			if taken == n {
+				s.cancel()
+				return
+			}
+		}
+	}()
+	return &Stream[T]{ctx: s.ctx, cancel: s.cancel, out: out}
+}
+
+// Skip returns a Stream of the elements of s after dropping the first n.
+func (s *Stream[T]) Skip(n int) *Stream[T] {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		skipped := 0
+		for v := range s.out {
+			if skipped < n {
+				skipped++
+				continue
+			}
+			select {
+			case <-s.ctx.Done():
+				return
+			case out <- v:
+			}
+		}
+	}()
+	return &Stream[T]{ctx: s.ctx, cancel: s.cancel, out: out}
+}
+
+// Sorted returns a Stream of the elements of s sorted by less. It is a
+// blocking operator: all of s is materialized before anything is emitted.
+func (s *Stream[T]) Sorted(less func(a, b T) bool) *Stream[T] {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		values := s.ToSlice()
+		sort.Slice(values, func(i, j int) bool { return less(values[i], values[j]) })
+		for _, v := range values {
+			select {
+			case <-s.ctx.Done():
+				return
+			case out <- v:
+			}
+		}
+	}()
+	return &Stream[T]{ctx: s.ctx, cancel: s.cancel, out: out}
+}
+
+// ForEach is a terminal operation that invokes fn for every element of s, in
+// order, blocking until the stream is exhausted or ctx is cancelled.
+func (s *Stream[T]) ForEach(fn func(T)) {
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case v, ok := <-s.out:
+			if !ok {
+				return
+			}
+			fn(v)
+		}
+	}
+}
+
+// ToSlice is a terminal operation that collects every element of s into a
+// slice.
+func (s *Stream[T]) ToSlice() []T {
+	var values []T
+	s.ForEach(func(v T) { values = append(values, v) })
+	return values
+}
+
+// Reduce is a terminal operation that folds s into a single value, starting
+// from initial and combining elements in order with fn.
+func (s *Stream[T]) Reduce(initial T, fn func(acc, v T) T) T {
+	acc := initial
+	s.ForEach(func(v T) { acc = fn(acc, v) })
+	return acc
+}
+
+// Any is a terminal operation reporting whether pred holds for at least one
+// element of s. It stops consuming, cancelling the upstream, as soon as it
+// finds a match.
+func (s *Stream[T]) Any(pred func(T) bool) bool {
+	for {
+		select {
+		case <-s.ctx.Done():
+			return false
+		case v, ok := <-s.out:
+			if !ok {
+				return false
+			}
+			if pred(v) {
+				s.cancel()
+				return true
+			}
+		}
+	}
+}
+
+// All is a terminal operation reporting whether pred holds for every element
+// of s. It stops consuming as soon as it finds a counterexample.
+func (s *Stream[T]) All(pred func(T) bool) bool {
+	return !s.Any(func(v T) bool { return !pred(v) })
+}
+
+// None is a terminal operation reporting whether pred holds for no element
+// of s.
+func (s *Stream[T]) None(pred func(T) bool) bool {
+	return !s.Any(pred)
+}
@@ -0,0 +1,115 @@
+package stream
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestFilterMapReduce(t *testing.T) {
+	ctx := context.Background()
+	ints := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	isOdd := func(n int) bool { return n%2 != 0 }
+	double := func(n int) int { return n * 2 }
+
+	got := Map(From(ctx, ints).Filter(isOdd), double).Reduce(0, func(acc, n int) int { return acc + n })
+
+	want := 0
+	for _, n := range ints {
+		if isOdd(n) {
+			want += double(n)
+		}
+	}
+	if got != want {
+		t.Errorf("got %d, want %d", got, want)
+	}
+}
+
+func TestToSlicePreservesOrder(t *testing.T) {
+	ctx := context.Background()
+	ints := []int{5, 3, 1, 4, 2}
+
+	got := From(ctx, ints).ToSlice()
+	if !reflect.DeepEqual(got, ints) {
+		t.Errorf("got %v, want %v", got, ints)
+	}
+}
+
+func TestTake(t *testing.T) {
+	ctx := context.Background()
+	ints := []int{1, 2, 3, 4, 5}
+
+	got := From(ctx, ints).Take(3).ToSlice()
+	want := []int{1, 2, 3}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestAnyAllNone(t *testing.T) {
+	ctx := context.Background()
+	ints := []int{2, 4, 6, 7, 8}
+
+	if !From(ctx, ints).Any(func(n int) bool { return n%2 != 0 }) {
+		t.Error("Any(odd) = false, want true")
+	}
+	if From(ctx, ints).All(func(n int) bool { return n%2 == 0 }) {
+		t.Error("All(even) = true, want false")
+	}
+	if From(ctx, ints).None(func(n int) bool { return n > 100 }) != true {
+		t.Error("None(>100) = false, want true")
+	}
+}
+
+// TestTakeDoesNotLeakUpstream guards against abandoned upstream goroutines:
+// Take stops reading well before the source is exhausted, so the producer
+// started by From must be cancelled rather than left blocked forever on a
+// send nobody will receive.
+func TestTakeDoesNotLeakUpstream(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	ints := make([]int, 100_000)
+	for i := range ints {
+		ints[i] = i
+	}
+
+	for i := 0; i < 20; i++ {
+		From(context.Background(), ints).Take(3).ToSlice()
+	}
+
+	const grace = time.Second
+	deadline := time.Now().Add(grace)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Fatalf("goroutines leaked: before=%d after=%d", before, after)
+	}
+}
+
+// TestAnyDoesNotLeakUpstream mirrors TestTakeDoesNotLeakUpstream for Any,
+// which can also stop consuming long before the source is exhausted.
+func TestAnyDoesNotLeakUpstream(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	ints := make([]int, 100_000)
+	for i := range ints {
+		ints[i] = i
+	}
+
+	for i := 0; i < 20; i++ {
+		From(context.Background(), ints).Any(func(n int) bool { return n == 3 })
+	}
+
+	const grace = time.Second
+	deadline := time.Now().Add(grace)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Fatalf("goroutines leaked: before=%d after=%d", before, after)
+	}
+}
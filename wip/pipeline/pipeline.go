@@ -6,106 +6,190 @@ package main
 
 import (
 	"context"
-	"sync"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/A2Y-D5L/interviews-pub/pipeline"
+	"github.com/A2Y-D5L/interviews-pub/stream"
 )
 
-// filterOddNumbers returns a channel emitting only the odd numbers from the input slice.
-// It listens to the context for cancellation.
-func filterOddNumbers(ctx context.Context, numbers []int) <-chan int {
-	out := make(chan int, len(numbers)) // Use buffered channel to reduce context switches.
+// concPipelinesTimeout bounds how long concPipelines will wait for every
+// slice to finish summing before giving up.
+const concPipelinesTimeout = 2 * time.Second
+
+// filterOddNumbers returns a channel emitting the odd numbers from numbers,
+// each wrapped in a pipeline.Result. It listens to ctx for cancellation,
+// including a deadline set by the caller.
+func filterOddNumbers(ctx context.Context, numbers []int) <-chan pipeline.Result[int] {
+	out := make(chan pipeline.Result[int])
 	go func() {
 		defer close(out)
 		for _, n := range numbers {
+			if n%2 == 0 {
+				continue
+			}
 			select {
 			case <-ctx.Done():
 				return
-			default:
-				if n%2 != 0 {
-					out <- n
-				}
+			case out <- pipeline.Result[int]{Value: n}:
 			}
 		}
 	}()
 	return out
 }
 
-// sumNumbers returns a channel emitting the sum of numbers received from the input channel.
-// It listens to the context for cancellation.
-func sumNumbers(ctx context.Context, in <-chan int) <-chan int {
-	out := make(chan int)
+// sumNumbers returns a channel emitting a single pipeline.Result holding
+// the sum of the values received from in. If in carries an error, that
+// error is forwarded downstream and the rest of in is drained so its
+// producer goroutine doesn't leak. It listens to ctx for cancellation,
+// including a deadline set by the caller.
+func sumNumbers(ctx context.Context, in <-chan pipeline.Result[int]) <-chan pipeline.Result[int] {
+	out := make(chan pipeline.Result[int])
 	go func() {
 		defer close(out)
 		sum := 0
 		for {
 			select {
-			case n, ok := <-in:
+			case <-ctx.Done():
+				return
+			case r, ok := <-in:
 				if !ok {
-					out <- sum
+					select {
+					case <-ctx.Done():
+					case out <- pipeline.Result[int]{Value: sum}:
+					}
 					return
 				}
-				sum += n
-			case <-ctx.Done():
-				return
+				if r.Err != nil {
+					pipeline.Drain(in)
+					select {
+					case <-ctx.Done():
+					case out <- r:
+					}
+					return
+				}
+				sum += r.Value
 			}
 		}
 	}()
 	return out
 }
 
-// getSumOfOdds returns the sum of odd integers in a slice of int.
+// getSumOfOdds returns the sum of odd integers in a slice of int. It is a
+// thin wrapper over the stream package, kept side by side with
+// filterOddNumbers/sumNumbers above to show the same computation expressed
+// as a lazy operator chain instead of hand-wired channels.
 func getSumOfOdds(ctx context.Context, ints []int) int {
-	pipeline := sumNumbers(ctx, filterOddNumbers(ctx, ints))
-	sum := 0
-	for {
-		select {
-		case val, ok := <-pipeline:
-			if !ok {
-				return sum
+	return stream.From(ctx, ints).
+		Filter(func(n int) bool { return n%2 != 0 }).
+		Reduce(0, func(acc, n int) int { return acc + n })
+}
+
+// concPipelinesGroupSize is how many input slices share a single
+// pipeline.Bounded worker. Each worker builds one filterOddNumbers|
+// sumNumbers pipeline per slice in its group and fans their outputs in with
+// pipeline.Merge, so the number of pipelines running at any moment is
+// capped at runtime.GOMAXPROCS(0) * concPipelinesGroupSize rather than one
+// unbounded goroutine pair per input slice.
+const concPipelinesGroupSize = 2
+
+// concPipelines returns the sum of odd integers from each slice in a
+// variadic slice of []int, or the first error encountered. Slices are
+// grouped into batches of concPipelinesGroupSize, and pipeline.Bounded caps
+// how many of those batches run at once at runtime.GOMAXPROCS(0); within a
+// batch, sumGroup builds one pipeline per slice and merges them with
+// pipeline.Merge. The whole call is bounded by concPipelinesTimeout, and
+// pipeline.Run - not a hand-rolled range/check-err/drain loop - decides
+// whether it succeeded.
+func concPipelines(ints ...[]int) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), concPipelinesTimeout)
+	defer cancel()
+
+	groups := groupSlices(ints, concPipelinesGroupSize)
+
+	in := make(chan [][]int, len(groups))
+	for _, g := range groups {
+		in <- g
+	}
+	close(in)
+
+	events := pipeline.Bounded(ctx, in, runtime.GOMAXPROCS(0), func(group [][]int) (int, error) {
+		return sumGroup(ctx, group)
+	})
+
+	totalSum := 0
+	results := make(chan pipeline.Result[int])
+	go func() {
+		defer close(results)
+		for ev := range events {
+			if ev.Err == nil {
+				totalSum += ev.Data
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case results <- pipeline.Result[int]{Value: ev.Data, Err: ev.Err}:
 			}
-			sum += val
-		case <-ctx.Done():
-			return sum // Return the sum calculated so far.
 		}
+	}()
+
+	if err := pipeline.Run(ctx, results); err != nil {
+		return 0, err
 	}
+	return totalSum, nil
 }
 
-// concPipelines returns the sum of odd integers from each slice in a variadic slice of []int.
-func concPipelines(ints ...[]int) int {
-	var wg sync.WaitGroup
-	ctx := context.Background()
-	results := make(chan int)
-
-	wg.Add(len(ints))
-
-	for _, is := range ints {
-		go func(ctx context.Context, ints []int) {
-			defer wg.Done()
-			pipeline := sumNumbers(ctx, filterOddNumbers(ctx, ints))
-			sum := 0
-			for {
-				select {
-				case val, ok := <-pipeline:
-					if !ok {
-						results <- sum
-						return
-					}
-					sum += val
-				case <-ctx.Done():
-					results <- sum // Return the sum calculated so far.
-					return
-				}
-			}
-		}(ctx, is)
+// sumGroup builds one filterOddNumbers|sumNumbers pipeline per slice in
+// group, fans their outputs in with pipeline.Merge, and returns their sum
+// or the first error encountered.
+func sumGroup(ctx context.Context, group [][]int) (int, error) {
+	sums := make([]<-chan pipeline.Result[int], len(group))
+	for i, is := range group {
+		sums[i] = sumNumbers(ctx, filterOddNumbers(ctx, is))
 	}
 
-	go func() {
-		wg.Wait()
-		close(results)
-	}()
+	merged := pipeline.Merge(ctx, sums...)
+	sum := 0
+	for r := range merged {
+		if r.Err != nil {
+			pipeline.Drain(merged)
+			return 0, r.Err
+		}
+		sum += r.Value
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return sum, nil
+}
 
-	totalSum := 0
-	for sum := range results {
-		totalSum += sum
+// groupSlices splits ints into chunks of at most size slices each.
+func groupSlices(ints [][]int, size int) [][][]int {
+	if size <= 0 {
+		size = 1
+	}
+	var groups [][][]int
+	for start := 0; start < len(ints); start += size {
+		end := start + size
+		if end > len(ints) {
+			end = len(ints)
+		}
+		groups = append(groups, ints[start:end])
+	}
+	return groups
+}
+
+func main() {
+	ctx := context.Background()
+	ints := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	fmt.Println("sum of odds:", getSumOfOdds(ctx, ints))
+
+	total, err := concPipelines(ints, ints, ints)
+	if err != nil {
+		fmt.Println("concPipelines error:", err)
+		return
 	}
-	return totalSum
+	fmt.Println("sum of odds across slices:", total)
 }
@@ -0,0 +1,49 @@
+package main
+
+import "testing"
+
+func TestConcPipelinesSumsAcrossSlices(t *testing.T) {
+	got, err := concPipelines([]int{1, 2, 3, 4, 5}, []int{10, 11, 12}, []int{7})
+	if err != nil {
+		t.Fatalf("concPipelines() error = %v, want nil", err)
+	}
+	want := (1 + 3 + 5) + 11 + 7
+	if got != want {
+		t.Fatalf("concPipelines() = %d, want %d", got, want)
+	}
+}
+
+func TestConcPipelinesNoSlices(t *testing.T) {
+	got, err := concPipelines()
+	if err != nil {
+		t.Fatalf("concPipelines() error = %v, want nil", err)
+	}
+	if got != 0 {
+		t.Fatalf("concPipelines() = %d, want 0", got)
+	}
+}
+
+// TestConcPipelinesExceedsGroupSize exercises many more slices than
+// concPipelinesGroupSize, so they are split into several groups and more
+// than one sumGroup Merge call is involved in producing the total.
+func TestConcPipelinesExceedsGroupSize(t *testing.T) {
+	slices := make([][]int, 20)
+	want := 0
+	for i := range slices {
+		slices[i] = []int{i, i + 1}
+		if i%2 != 0 {
+			want += i
+		}
+		if (i+1)%2 != 0 {
+			want += i + 1
+		}
+	}
+
+	got, err := concPipelines(slices...)
+	if err != nil {
+		t.Fatalf("concPipelines() error = %v, want nil", err)
+	}
+	if got != want {
+		t.Fatalf("concPipelines() = %d, want %d", got, want)
+	}
+}